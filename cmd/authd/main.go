@@ -0,0 +1,90 @@
+package main
+
+import (
+	"crypto/rand"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/template/html/v2"
+	"github.com/joho/godotenv"
+	"github.com/lucsky/cuid"
+	"gorm.io/gorm/clause"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/database"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/api"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+func main() {
+	err := godotenv.Load()
+	if err != nil {
+		panic("Error loading .env file")
+	}
+
+	sessionSecret := os.Getenv("SESSION_SECRET")
+	if sessionSecret == "" {
+		panic("SESSION_SECRET environment variable not set")
+	}
+
+	issuer := os.Getenv("ISSUER_URL")
+	if issuer == "" {
+		panic("ISSUER_URL environment variable not set")
+	}
+
+	db, err := database.Connect()
+	if err != nil {
+		panic("Failed to connect to database")
+	}
+
+	if err := database.Migrate(db); err != nil {
+		panic("Migration failed")
+	}
+
+	svc := services.New(db, sessionSecret)
+
+	//Generate client secret
+	clientSecret, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		panic("Failed to generate client secret")
+	}
+
+	// Insert dummy client OnConflict allows an update when ID conflicts or matches again.
+	db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "website", "redirect_uri", "logo", "client_secret"}),
+	}).Create(&models.Client{
+		ID:           "1",
+		Name:         "fiber",
+		Website:      "http://localhost:8080",
+		RedirectURI:  "http://localhost:8080/auth/callback",
+		Logo:         "https://placehold.co/600x400",
+		ClientSecret: clientSecret,
+	})
+
+	views := html.New("./views", ".html")
+
+	app := fiber.New(fiber.Config{
+		AppName: "Authorization Service",
+		Views:   views,
+	})
+
+	app.Use(logger.New())
+	app.Use(recover.New())
+	app.Use(exts.UserMiddleware(svc))
+
+	api.RegisterRoutes(app, svc, issuer)
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "3000"
+	}
+
+	err = app.Listen(":" + port)
+	if err != nil {
+		panic("API has failed")
+	}
+}
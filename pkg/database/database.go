@@ -0,0 +1,37 @@
+package database
+
+import (
+	"errors"
+	"os"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+// Connect opens the Postgres connection configured by DATABASE_URL.
+func Connect() (*gorm.DB, error) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		return nil, errors.New("DATABASE_URL environment variable not set")
+	}
+
+	return gorm.Open(postgres.Open(dbURL), &gorm.Config{})
+}
+
+// Migrate runs AutoMigrate for every model the service owns.
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&models.Client{},
+		&models.Account{},
+		&models.AuthChallenge{},
+		&models.AuthFactor{},
+		&models.AuthorizationCode{},
+		&models.RefreshToken{},
+		&models.SigningKey{},
+		&models.RevokedToken{},
+		&models.ActionEvent{},
+		&models.Ticket{},
+	)
+}
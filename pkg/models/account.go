@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	FactorPassword = "password"
+	FactorEmailOTP = "email_otp"
+	FactorTOTP     = "totp"
+)
+
+// Account is a registered end user that logs in and grants consent to
+// clients. Credentials live entirely in AuthFactor, so an Account has no
+// password of its own — logging in always means satisfying a challenge.
+type Account struct {
+	ID        string `gorm:"primaryKey"`
+	Email     string `gorm:"uniqueIndex"`
+	Nick      string
+	Verified  bool           `gorm:"default:false"`
+	IsAdmin   bool           `gorm:"default:false" json:"-"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// AuthChallenge tracks an in-progress login: how many factors are still
+// required before the server will mint a session for AccountID.
+type AuthChallenge struct {
+	ID               string `gorm:"primaryKey"`
+	AccountID        string
+	IP               string
+	UserAgent        string
+	RemainingFactors int
+	ExpiresAt        time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// AuthFactor is a credential an Account can satisfy a challenge with.
+type AuthFactor struct {
+	ID        string `gorm:"primaryKey"`
+	AccountID string
+	Type      string // password, email_otp, totp
+	Secret    string `json:"-"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
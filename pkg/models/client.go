@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const AlgRS256 = "RS256"
+
+// Client is a registered OAuth client allowed to request authorization on
+// behalf of an Account.
+type Client struct {
+	ID           string `gorm:"primaryKey"`
+	Name         string `gorm:"uniqueIndex"`
+	ClientSecret string `json:"-"`
+	Website      string
+	Logo         string
+	RedirectURI  string         `json:"redirect_uri"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	EventAuthorizeRequested = "authorize_requested"
+	EventLogin              = "login"
+	EventConsentGranted     = "consent_granted"
+	EventConsentDenied      = "consent_denied"
+	EventTokenIssued        = "token_issued"
+	EventTokenRefreshed     = "token_refreshed"
+	EventTokenRevoked       = "token_revoked"
+	EventChallengeFailed    = "challenge_failed"
+)
+
+// ActionEvent is an audit log entry recording a security-sensitive action an
+// Account (or, for a client_credentials grant, no Account) took. ClientID is
+// empty for events with no associated client, such as a login or a failed
+// challenge.
+type ActionEvent struct {
+	ID        string `gorm:"primaryKey"`
+	AccountID string `gorm:"index"`
+	ClientID  string `gorm:"index"`
+	Action    string `gorm:"index"`
+	Target    string
+	IP        string
+	UserAgent string
+	CreatedAt time.Time
+}
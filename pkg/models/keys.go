@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// SigningKey is an RSA keypair used to sign access tokens, identified by kid
+// in the token header. Keys rotate; NotBefore/NotAfter bound when a key is
+// the active signer, and old keys stay around in the JWKS until NotAfter so
+// tokens they already signed keep verifying.
+type SigningKey struct {
+	Kid        string `gorm:"primaryKey"`
+	Alg        string
+	PrivateKey string `json:"-"`
+	PublicKey  string `json:"-"`
+	NotBefore  time.Time
+	NotAfter   time.Time
+	CreatedAt  time.Time
+}
+
+// RevokedToken marks an access token's jti as revoked ahead of its natural
+// expiry (RFC 7009).
+type RevokedToken struct {
+	JTI       string `gorm:"primaryKey"`
+	RevokedAt time.Time
+}
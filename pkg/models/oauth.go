@@ -0,0 +1,35 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AuthorizationCode is a single-use code minted once an Account grants
+// consent, redeemed by the client at /token for an access token. It replaces
+// the earlier per-Client code column, which couldn't support concurrent
+// logins across users of the same client.
+type AuthorizationCode struct {
+	Code        string `gorm:"primaryKey"`
+	ClientID    string
+	AccountID   string
+	RedirectURI string
+	Scope       string
+	Challenge   string
+	Method      string // plain, S256
+	Used        bool   `gorm:"default:false"`
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// RefreshToken is a long-lived, rotatable, revocable credential a client
+// exchanges for a fresh access token without the account re-authenticating.
+type RefreshToken struct {
+	Token     string `gorm:"primaryKey"`
+	ClientID  string
+	AccountID string
+	Scope     string
+	ExpiresAt time.Time
+	RevokedAt sql.NullTime
+	CreatedAt time.Time
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Ticket is the server-side record of an access token issued at /token: a
+// "logged-in devices" entry an Account can list and revoke remotely. The
+// JWT middleware updates LastUsedAt on every request and rejects the token
+// once RevokedAt is set.
+type Ticket struct {
+	ID           string `gorm:"primaryKey"`
+	AccountID    string `gorm:"index"`
+	ClientID     string
+	AccessJTI    string `gorm:"uniqueIndex"`
+	RefreshToken string
+	IP           string
+	UserAgent    string
+	Location     string
+	LastUsedAt   time.Time
+	ExpiresAt    time.Time
+	RevokedAt    sql.NullTime
+	CreatedAt    time.Time
+}
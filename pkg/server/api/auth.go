@@ -0,0 +1,106 @@
+package api
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lucsky/cuid"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+// AuthHandler records the authorization request and renders the consent
+// page for a logged-in Account, stashing the pending request's scope and
+// PKCE challenge for ConfirmAuthHandler to pick back up.
+func AuthHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authRequest := new(AuthRequest)
+		if err := exts.BindAndValidate(c, authRequest); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid auth request"})
+		}
+
+		client, err := svc.LookupClient(authRequest.ClientID)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid client"})
+		}
+
+		user := exts.CurrentUser(c)
+		_ = svc.AddEvent(user.ID, client.ID, models.EventAuthorizeRequested, "", c.IP(), c.Get("User-Agent"))
+
+		nonce, err := cuid.NewCrypto(rand.Reader)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "server error"})
+		}
+
+		pending := fiber.Cookie{
+			Secure:   true,
+			Expires:  time.Now().Add(1 * time.Minute),
+			HTTPOnly: true,
+		}
+
+		pending.Name, pending.Value = "temp_auth_request_code", nonce
+		c.Cookie(&pending)
+
+		pending.Name, pending.Value = "temp_auth_request_scope", authRequest.Scope
+		c.Cookie(&pending)
+
+		pending.Name, pending.Value = "temp_auth_request_code_challenge", authRequest.CodeChallenge
+		c.Cookie(&pending)
+
+		pending.Name, pending.Value = "temp_auth_request_code_challenge_method", authRequest.CodeChallengeMethod
+		c.Cookie(&pending)
+
+		return c.Render("authorize_client", fiber.Map{
+			"Logo":    client.Logo,
+			"Name":    client.Name,
+			"Website": client.Website,
+			"State":   authRequest.State,
+			"Scopes":  strings.Split(authRequest.Scope, " "),
+		})
+	}
+}
+
+// ConfirmAuthHandler issues the authorization code once the Account approves
+// (or redirects with access_denied when it doesn't).
+func ConfirmAuthHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := exts.CurrentUser(c)
+
+		pendingNonce := c.Cookies("temp_auth_request_code")
+		scope := c.Cookies("temp_auth_request_scope")
+		codeChallenge := c.Cookies("temp_auth_request_code_challenge")
+		codeChallengeMethod := c.Cookies("temp_auth_request_code_challenge_method")
+		c.ClearCookie("temp_auth_request_code", "temp_auth_request_scope", "temp_auth_request_code_challenge", "temp_auth_request_code_challenge_method")
+		if pendingNonce == "" {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid code request"})
+		}
+
+		confirmAuthRequest := new(ConfirmAuthRequest)
+		if err := exts.BindAndValidate(c, confirmAuthRequest); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid confirm auth request"})
+		}
+
+		client, err := svc.LookupClient(confirmAuthRequest.ClientID)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid client"})
+		}
+
+		if !confirmAuthRequest.Authorize {
+			_ = svc.AddEvent(user.ID, client.ID, models.EventConsentDenied, "", c.IP(), c.Get("User-Agent"))
+			return c.Redirect(client.RedirectURI + "?error=access_denied" + "&state=" + confirmAuthRequest.State)
+		}
+
+		authCode, err := svc.IssueCode(client, user.ID, scope, codeChallenge, codeChallengeMethod)
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		_ = svc.AddEvent(user.ID, client.ID, models.EventConsentGranted, "", c.IP(), c.Get("User-Agent"))
+
+		return c.Redirect(client.RedirectURI + "?code=" + authCode.Code + "&state=" + confirmAuthRequest.State)
+	}
+}
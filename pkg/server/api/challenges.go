@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+type factorSummary struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// StartChallengeHandler begins an MFA login for an account, returning the
+// challenge id and the factors it must be satisfied with.
+func StartChallengeHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		startRequest := new(StartChallengeRequest)
+		if err := exts.BindAndValidate(c, startRequest); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid challenge request"})
+		}
+
+		if _, err := svc.LookupAccount(startRequest.AccountID); err != nil {
+			return c.Status(404).JSON(fiber.Map{"error": "account not found"})
+		}
+
+		challenge, factors, err := svc.StartChallenge(startRequest.AccountID, c.IP(), c.Get("User-Agent"))
+		if err != nil {
+			if errors.Is(err, services.ErrNoFactorsEnrolled) {
+				return c.Status(400).JSON(fiber.Map{"error": "no_factors_enrolled"})
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		summaries := make([]factorSummary, len(factors))
+		for i, factor := range factors {
+			summaries[i] = factorSummary{ID: factor.ID, Type: factor.Type}
+		}
+
+		return c.Status(201).JSON(fiber.Map{
+			"challenge_id": challenge.ID,
+			"factors":      summaries,
+		})
+	}
+}
+
+// VerifyChallengeHandler verifies one factor of a challenge, logging the
+// account in once every factor has been satisfied.
+func VerifyChallengeHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		verifyRequest := new(VerifyChallengeRequest)
+		if err := exts.BindAndValidate(c, verifyRequest); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid_factor"})
+		}
+
+		challenge, err := svc.VerifyChallengeFactor(c.Params("id"), verifyRequest.FactorID, verifyRequest.Secret)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidFactorSecret) {
+				_ = svc.AddEvent("", "", models.EventChallengeFailed, c.Params("id"), c.IP(), c.Get("User-Agent"))
+			}
+
+			switch {
+			case errors.Is(err, services.ErrChallengeExpired):
+				return c.Status(400).JSON(fiber.Map{"error": "challenge_expired"})
+			case errors.Is(err, services.ErrInvalidFactor):
+				return c.Status(400).JSON(fiber.Map{"error": "invalid_factor"})
+			case errors.Is(err, services.ErrInvalidFactorSecret):
+				return c.Status(401).JSON(fiber.Map{"error": "invalid_factor_secret"})
+			default:
+				return c.Status(404).JSON(fiber.Map{"error": "challenge not found"})
+			}
+		}
+
+		if challenge.RemainingFactors > 0 {
+			return c.JSON(fiber.Map{"status": "pending", "remaining_factors": challenge.RemainingFactors})
+		}
+
+		if err := logIn(c, svc, challenge.AccountID); err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		_ = svc.AddEvent(challenge.AccountID, "", models.EventLogin, "", c.IP(), c.Get("User-Agent"))
+
+		return c.JSON(fiber.Map{"status": "complete"})
+	}
+}
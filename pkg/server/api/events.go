@@ -0,0 +1,37 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+// MeEventsHandler returns the logged-in Account's own audit log, paginated
+// with the limit/offset query params.
+func MeEventsHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := exts.CurrentUser(c)
+
+		events, err := svc.ListAccountEvents(user.ID, c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		return c.JSON(fiber.Map{"events": events})
+	}
+}
+
+// AdminEventsHandler returns the audit log across every Account, optionally
+// filtered by the account_id/client_id/action query params and paginated
+// with limit/offset.
+func AdminEventsHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		events, err := svc.ListEvents(c.Query("account_id"), c.Query("client_id"), c.Query("action"), c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		return c.JSON(fiber.Map{"events": events})
+	}
+}
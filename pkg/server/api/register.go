@@ -0,0 +1,32 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+// RegisterHandler creates a new Account with a password AuthFactor enrolled,
+// so it can start a challenge and log in. There's otherwise no way for an
+// Account to come into existence.
+func RegisterHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		registerRequest := new(RegisterRequest)
+		if err := exts.BindAndValidate(c, registerRequest); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid register request"})
+		}
+
+		account, err := svc.RegisterAccount(registerRequest.Email, registerRequest.Nick, registerRequest.Password)
+		if err != nil {
+			if errors.Is(err, services.ErrEmailTaken) {
+				return c.Status(409).JSON(fiber.Map{"error": "email_taken"})
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		return c.Status(201).JSON(account)
+	}
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+// RegisterRoutes wires every handler in this package onto app.
+func RegisterRoutes(app *fiber.App, svc *services.Service, issuer string) {
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("hello!")
+	})
+
+	app.Post("/register", RegisterHandler(svc))
+
+	app.Post("/challenges", StartChallengeHandler(svc))
+	app.Patch("/challenges/:id", VerifyChallengeHandler(svc))
+
+	app.Get("/auth", exts.EnsureAuthenticated, AuthHandler(svc))
+	app.Get("/confirm_auth", exts.EnsureAuthenticated, ConfirmAuthHandler(svc))
+
+	app.Post("/token", TokenHandler(svc))
+	app.Post("/introspect", IntrospectHandler(svc))
+	app.Post("/revoke", RevokeHandler(svc))
+
+	app.Get("/me/events", exts.EnsureAuthenticated, MeEventsHandler(svc))
+	app.Get("/admin/events", exts.EnsureAuthenticated, exts.EnsureAdmin, AdminEventsHandler(svc))
+
+	app.Get("/me/tickets", exts.EnsureAuthenticated, MeTicketsHandler(svc))
+	app.Delete("/me/tickets/:ticketId", exts.EnsureAuthenticated, RevokeTicketHandler(svc))
+
+	app.Get("/.well-known/openid-configuration", OpenIDConfigurationHandler(issuer))
+	app.Get("/.well-known/jwks.json", JWKSHandler(svc))
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+// logIn signs a session token for accountID and sets it as the session-token
+// cookie, logging the account in.
+func logIn(c *fiber.Ctx, svc *services.Service, accountID string) error {
+	signed, expires, err := svc.IssueSessionToken(accountID)
+	if err != nil {
+		return err
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     "session_token",
+		Value:    signed,
+		Secure:   true,
+		HTTPOnly: true,
+		Expires:  expires,
+	})
+
+	return nil
+}
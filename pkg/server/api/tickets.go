@@ -0,0 +1,43 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+// MeTicketsHandler lists the logged-in Account's sessions - one per access
+// token ever issued to it - so it can be rendered as a "logged-in devices"
+// screen.
+func MeTicketsHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := exts.CurrentUser(c)
+
+		tickets, err := svc.ListAccountTickets(user.ID)
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		return c.JSON(fiber.Map{"tickets": tickets})
+	}
+}
+
+// RevokeTicketHandler kills one of the logged-in Account's own sessions.
+// Once revoked, the JWT middleware rejects the access token that session was
+// issued for.
+func RevokeTicketHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := exts.CurrentUser(c)
+
+		ticket, err := svc.RevokeTicket(user.ID, c.Params("ticketId"))
+		if err != nil {
+			return c.Status(404).JSON(fiber.Map{"error": "ticket not found"})
+		}
+
+		_ = svc.AddEvent(user.ID, ticket.ClientID, models.EventTokenRevoked, ticket.ID, c.IP(), c.Get("User-Agent"))
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
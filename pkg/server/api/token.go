@@ -0,0 +1,82 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+// TokenHandler authenticates the client and dispatches to the grant-specific
+// handler for grant_type.
+func TokenHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokenRequest := new(TokenRequest)
+		if err := exts.BindAndValidate(c, tokenRequest); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid_request"})
+		}
+
+		client, err := svc.AuthenticateClient(tokenRequest.ClientID, tokenRequest.ClientSecret)
+		if err != nil {
+			return c.Status(401).JSON(fiber.Map{"error": "invalid_client"})
+		}
+
+		switch tokenRequest.GrantType {
+		case "authorization_code":
+			return authorizationCodeGrant(c, svc, client, tokenRequest)
+		case "refresh_token":
+			return refreshTokenGrant(c, svc, client, tokenRequest)
+		case "client_credentials":
+			return clientCredentialsGrant(c, svc, client)
+		default:
+			return c.Status(400).JSON(fiber.Map{"error": "unsupported_grant_type"})
+		}
+	}
+}
+
+func authorizationCodeGrant(c *fiber.Ctx, svc *services.Service, client *models.Client, tokenRequest *TokenRequest) error {
+	authCode, err := svc.ExchangeCode(client, tokenRequest.Code, tokenRequest.RedirectURI, tokenRequest.CodeVerifier)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid_grant"})
+	}
+
+	pair, err := svc.MintTokens(client, authCode.AccountID, authCode.Scope, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	_ = svc.AddEvent(authCode.AccountID, client.ID, models.EventTokenIssued, "", c.IP(), c.Get("User-Agent"))
+
+	return c.Status(200).JSON(tokenResponse(pair))
+}
+
+func refreshTokenGrant(c *fiber.Ctx, svc *services.Service, client *models.Client, tokenRequest *TokenRequest) error {
+	pair, err := svc.RedeemRefreshToken(client, tokenRequest.RefreshToken, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid_grant"})
+	}
+
+	_ = svc.AddEvent(pair.AccountID, client.ID, models.EventTokenRefreshed, "", c.IP(), c.Get("User-Agent"))
+
+	return c.Status(200).JSON(tokenResponse(pair))
+}
+
+func clientCredentialsGrant(c *fiber.Ctx, svc *services.Service, client *models.Client) error {
+	pair, err := svc.MintTokens(client, "", "", c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	_ = svc.AddEvent("", client.ID, models.EventTokenIssued, "", c.IP(), c.Get("User-Agent"))
+
+	return c.Status(200).JSON(tokenResponse(pair))
+}
+
+func tokenResponse(pair *services.TokenPair) TokenResponse {
+	return TokenResponse{
+		AccessToken:  pair.AccessToken,
+		ExpiresIn:    pair.ExpiresIn,
+		RefreshToken: pair.RefreshToken,
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+type AuthRequest struct {
+	ResponseType        string `json:"response_type" query:"response_type" validate:"required,eq=code"`
+	ClientID            string `json:"client_id" query:"client_id" validate:"required"`
+	RedirectURI         string `json:"redirect_uri" query:"redirect_uri" validate:"required,contains=https"`
+	CodeChallenge       string `json:"code_challenge" query:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method" query:"code_challenge_method"`
+	Scope               string `validate:"required"`
+	State               string `validate:"required"`
+}
+
+type ConfirmAuthRequest struct {
+	Authorize bool   `json:"authorize" query:"authorize"`
+	ClientID  string `json:"client_id" query:"client_id" validate:"required"`
+	State     string `validate:"required"`
+}
+
+type TokenRequest struct {
+	GrantType    string `json:"grant_type" validate:"required,oneof=authorization_code refresh_token client_credentials"`
+	Code         string `validate:"required_if=GrantType authorization_code"`
+	RedirectURI  string `json:"redirect_uri" validate:"required_if=GrantType authorization_code"`
+	ClientID     string `json:"client_id" validate:"required"`
+	ClientSecret string `json:"client_secret" validate:"required"`
+	CodeVerifier string `json:"code_verifier"`
+	RefreshToken string `json:"refresh_token" validate:"required_if=GrantType refresh_token"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+type RegisterRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Nick     string `json:"nick" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+type StartChallengeRequest struct {
+	AccountID string `json:"account_id" validate:"required"`
+}
+
+type VerifyChallengeRequest struct {
+	FactorID string `json:"factor_id" validate:"required"`
+	Secret   string `json:"secret" validate:"required"`
+}
+
+type IntrospectRequest struct {
+	Token        string `json:"token" validate:"required"`
+	ClientID     string `json:"client_id" validate:"required"`
+	ClientSecret string `json:"client_secret" validate:"required"`
+}
+
+type RevokeRequest struct {
+	Token        string `json:"token" validate:"required"`
+	ClientID     string `json:"client_id" validate:"required"`
+	ClientSecret string `json:"client_secret" validate:"required"`
+}
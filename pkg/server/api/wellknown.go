@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/base64"
+	"math/big"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/server/exts"
+	"github.com/unsubstantiated-Script/GoAuth/pkg/services"
+)
+
+// OpenIDConfigurationHandler serves the OIDC discovery document.
+func OpenIDConfigurationHandler(issuer string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{
+			"issuer":                                issuer,
+			"authorization_endpoint":                issuer + "/auth",
+			"token_endpoint":                        issuer + "/token",
+			"jwks_uri":                              issuer + "/.well-known/jwks.json",
+			"introspection_endpoint":                issuer + "/introspect",
+			"revocation_endpoint":                   issuer + "/revoke",
+			"response_types_supported":              []string{"code"},
+			"grant_types_supported":                 []string{"authorization_code", "refresh_token", "client_credentials"},
+			"code_challenge_methods_supported":      []string{"plain", "S256"},
+			"id_token_signing_alg_values_supported": []string{models.AlgRS256},
+		})
+	}
+}
+
+// JWKSHandler serves the set of RSA public keys resource servers use to
+// verify access tokens.
+func JWKSHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		keys, err := svc.ActiveSigningKeys()
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		jwks := make([]fiber.Map, 0, len(keys))
+		for _, key := range keys {
+			publicKey, err := services.ParseRSAPublicKey(key.PublicKey)
+			if err != nil {
+				continue
+			}
+
+			jwks = append(jwks, fiber.Map{
+				"kty": "RSA",
+				"use": "sig",
+				"alg": key.Alg,
+				"kid": key.Kid,
+				"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		}
+
+		return c.JSON(fiber.Map{"keys": jwks})
+	}
+}
+
+// IntrospectHandler implements RFC 7662 token introspection. Per the RFC the
+// caller must authenticate as a client, and is only told about tokens that
+// were issued to it - any other token comes back inactive.
+func IntrospectHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		introspectRequest := new(IntrospectRequest)
+		if err := exts.BindAndValidate(c, introspectRequest); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid_request"})
+		}
+
+		client, err := svc.AuthenticateClient(introspectRequest.ClientID, introspectRequest.ClientSecret)
+		if err != nil {
+			return c.Status(401).JSON(fiber.Map{"error": "invalid_client"})
+		}
+
+		claims, err := svc.ParseAccessToken(introspectRequest.Token)
+		if err != nil {
+			return c.JSON(fiber.Map{"active": false})
+		}
+
+		jti, _ := claims["jti"].(string)
+		if jti == "" {
+			return c.JSON(fiber.Map{"active": false})
+		}
+
+		ticket, err := svc.TicketByJTI(jti)
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		if ticket == nil || ticket.ClientID != client.ID {
+			return c.JSON(fiber.Map{"active": false})
+		}
+
+		if svc.IsRevoked(jti) || svc.ValidateTicket(jti) != nil {
+			return c.JSON(fiber.Map{"active": false})
+		}
+
+		exp, _ := claims["exp"].(float64)
+		if exp != 0 && time.Now().After(time.Unix(int64(exp), 0)) {
+			return c.JSON(fiber.Map{"active": false})
+		}
+
+		response := fiber.Map{"active": true, "exp": int64(exp)}
+		if sub, ok := claims["user_id"].(string); ok {
+			response["sub"] = sub
+		}
+		if scope, ok := claims["scope"].(string); ok {
+			response["scope"] = scope
+		}
+
+		return c.JSON(response)
+	}
+}
+
+// RevokeHandler implements RFC 7009 token revocation. Per the RFC the caller
+// must authenticate as a client, and can only revoke tokens issued to it -
+// presenting another client's token is treated the same as an unrecognized
+// one, with no error and nothing revoked.
+func RevokeHandler(svc *services.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		revokeRequest := new(RevokeRequest)
+		if err := exts.BindAndValidate(c, revokeRequest); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid_request"})
+		}
+
+		client, err := svc.AuthenticateClient(revokeRequest.ClientID, revokeRequest.ClientSecret)
+		if err != nil {
+			return c.Status(401).JSON(fiber.Map{"error": "invalid_client"})
+		}
+
+		claims, err := svc.ParseAccessToken(revokeRequest.Token)
+		if err != nil {
+			// RFC 7009: an unrecognized token is not an error.
+			return c.SendStatus(fiber.StatusOK)
+		}
+
+		jti, _ := claims["jti"].(string)
+		if jti == "" {
+			return c.SendStatus(fiber.StatusOK)
+		}
+
+		ticket, err := svc.TicketByJTI(jti)
+		if err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		if ticket == nil || ticket.ClientID != client.ID {
+			return c.SendStatus(fiber.StatusOK)
+		}
+
+		if err := svc.Revoke(jti); err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		if _, err := svc.RevokeTicketByJTI(jti); err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		accountID, _ := claims["user_id"].(string)
+		_ = svc.AddEvent(accountID, client.ID, models.EventTokenRevoked, jti, c.IP(), c.Get("User-Agent"))
+
+		return c.SendStatus(fiber.StatusOK)
+	}
+}
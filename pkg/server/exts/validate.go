@@ -0,0 +1,25 @@
+package exts
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+var validate = validator.New()
+
+// BindAndValidate parses the request body (or query string, for GET/DELETE)
+// into out and runs struct validation tags against it, replacing the
+// repetitive "if x == ''" checks handlers used to do by hand.
+func BindAndValidate(c *fiber.Ctx, out interface{}) error {
+	var err error
+	if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodDelete {
+		err = c.QueryParser(out)
+	} else {
+		err = c.BodyParser(out)
+	}
+	if err != nil {
+		return err
+	}
+
+	return validate.Struct(out)
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"crypto/rand"
+
+	"github.com/lucsky/cuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+// LookupAccount finds an Account by ID.
+func (s *Service) LookupAccount(id string) (*models.Account, error) {
+	account := new(models.Account)
+	if err := s.DB.First(account, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// RegisterAccount creates a new Account along with the password AuthFactor
+// it logs in with, since AuthFactor is the only place a credential is
+// stored. The Account can start a challenge immediately after.
+func (s *Service) RegisterAccount(email, nick, password string) (*models.Account, error) {
+	var existing int64
+	if err := s.DB.Model(&models.Account{}).Where("email = ?", email).Count(&existing).Error; err != nil {
+		return nil, err
+	}
+	if existing > 0 {
+		return nil, ErrEmailTaken
+	}
+
+	accountID, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	factorID, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &models.Account{
+		ID:    accountID,
+		Email: email,
+		Nick:  nick,
+	}
+
+	err = s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.AuthFactor{
+			ID:        factorID,
+			AccountID: accountID,
+			Type:      models.FactorPassword,
+			Secret:    string(hashed),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
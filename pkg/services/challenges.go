@@ -0,0 +1,97 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"time"
+
+	"github.com/lucsky/cuid"
+	"github.com/pquerna/otp/totp"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+const challengeTTL = 10 * time.Minute
+
+// StartChallenge begins an MFA login for account, returning the challenge
+// and the factors it must be satisfied with.
+func (s *Service) StartChallenge(accountID, ip, userAgent string) (*models.AuthChallenge, []models.AuthFactor, error) {
+	var factors []models.AuthFactor
+	if err := s.DB.Where("account_id = ?", accountID).Find(&factors).Error; err != nil {
+		return nil, nil, err
+	}
+
+	if len(factors) == 0 {
+		return nil, nil, ErrNoFactorsEnrolled
+	}
+
+	challengeID, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	challenge := &models.AuthChallenge{
+		ID:               challengeID,
+		AccountID:        accountID,
+		IP:               ip,
+		UserAgent:        userAgent,
+		RemainingFactors: len(factors),
+		ExpiresAt:        time.Now().Add(challengeTTL),
+	}
+
+	if err := s.DB.Create(challenge).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return challenge, factors, nil
+}
+
+// VerifyChallengeFactor checks secret against factorID and decrements the
+// challenge's remaining factor count. When it reaches zero the challenge is
+// satisfied and the caller should log the account in.
+func (s *Service) VerifyChallengeFactor(challengeID, factorID, secret string) (*models.AuthChallenge, error) {
+	challenge := new(models.AuthChallenge)
+	if err := s.DB.First(challenge, "id = ?", challengeID).Error; err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(challenge.ExpiresAt) {
+		return nil, ErrChallengeExpired
+	}
+
+	factor := new(models.AuthFactor)
+	if err := s.DB.Where("id = ? AND account_id = ?", factorID, challenge.AccountID).First(factor).Error; err != nil {
+		return nil, ErrInvalidFactor
+	}
+
+	if !verifyFactor(factor, secret) {
+		return nil, ErrInvalidFactorSecret
+	}
+
+	challenge.RemainingFactors--
+	if challenge.RemainingFactors < 0 {
+		challenge.RemainingFactors = 0
+	}
+
+	if err := s.DB.Model(challenge).Update("remaining_factors", challenge.RemainingFactors).Error; err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// verifyFactor checks secret against factor's stored credential according to
+// its type.
+func verifyFactor(factor *models.AuthFactor, secret string) bool {
+	switch factor.Type {
+	case models.FactorPassword:
+		return bcrypt.CompareHashAndPassword([]byte(factor.Secret), []byte(secret)) == nil
+	case models.FactorTOTP:
+		return totp.Validate(secret, factor.Secret)
+	case models.FactorEmailOTP:
+		return subtle.ConstantTimeCompare([]byte(factor.Secret), []byte(secret)) == 1
+	default:
+		return false
+	}
+}
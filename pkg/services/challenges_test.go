@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+func TestVerifyFactorPassword(t *testing.T) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword: %v", err)
+	}
+
+	factor := &models.AuthFactor{Type: models.FactorPassword, Secret: string(hashed)}
+
+	if !verifyFactor(factor, "correct horse") {
+		t.Error("expected correct password to verify")
+	}
+	if verifyFactor(factor, "wrong password") {
+		t.Error("expected wrong password to fail")
+	}
+}
+
+func TestVerifyFactorEmailOTP(t *testing.T) {
+	factor := &models.AuthFactor{Type: models.FactorEmailOTP, Secret: "123456"}
+
+	if !verifyFactor(factor, "123456") {
+		t.Error("expected matching OTP to verify")
+	}
+	if verifyFactor(factor, "000000") {
+		t.Error("expected mismatched OTP to fail")
+	}
+}
+
+func TestVerifyFactorUnknownType(t *testing.T) {
+	factor := &models.AuthFactor{Type: "carrier_pigeon", Secret: "whatever"}
+
+	if verifyFactor(factor, "whatever") {
+		t.Error("expected an unrecognized factor type to never verify")
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import "github.com/unsubstantiated-Script/GoAuth/pkg/models"
+
+// LookupClient finds a Client by its public name (what OAuth requests call
+// client_id in this service).
+func (s *Service) LookupClient(name string) (*models.Client, error) {
+	client := new(models.Client)
+	if err := s.DB.Where("name = ?", name).First(client).Error; err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// AuthenticateClient verifies clientSecret against the Client named
+// clientID, the same check /token applies to a grant request. /introspect
+// and /revoke use it too, since RFC 7662/7009 both require the caller to
+// authenticate as the client before acting on a token.
+func (s *Service) AuthenticateClient(clientID, clientSecret string) (*models.Client, error) {
+	client, err := s.LookupClient(clientID)
+	if err != nil {
+		return nil, ErrInvalidClient
+	}
+
+	if client.ClientSecret != clientSecret {
+		return nil, ErrInvalidClient
+	}
+
+	return client, nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+
+	"github.com/lucsky/cuid"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+const authCodeTTL = 2 * time.Minute
+
+// IssueCode mints the single-use authorization code a client redeems at
+// /token once an Account has granted consent.
+func (s *Service) IssueCode(client *models.Client, accountID, scope, challenge, method string) (*models.AuthorizationCode, error) {
+	code, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	authCode := &models.AuthorizationCode{
+		Code:        code,
+		ClientID:    client.ID,
+		AccountID:   accountID,
+		RedirectURI: client.RedirectURI,
+		Scope:       scope,
+		Challenge:   challenge,
+		Method:      method,
+		ExpiresAt:   time.Now().Add(authCodeTTL),
+	}
+
+	if err := s.DB.Create(authCode).Error; err != nil {
+		return nil, err
+	}
+
+	return authCode, nil
+}
+
+// ExchangeCode redeems a single-use AuthorizationCode, verifying its redirect
+// URI and PKCE challenge, and marks it used. Consuming the code is a
+// conditional update so two concurrent exchanges of the same code can't
+// both succeed.
+func (s *Service) ExchangeCode(client *models.Client, code, redirectURI, verifier string) (*models.AuthorizationCode, error) {
+	authCode := new(models.AuthorizationCode)
+	if err := s.DB.First(authCode, "code = ? AND client_id = ?", code, client.ID).Error; err != nil {
+		return nil, ErrInvalidGrant
+	}
+
+	if authCode.Used || time.Now().After(authCode.ExpiresAt) {
+		return nil, ErrInvalidGrant
+	}
+
+	if authCode.RedirectURI != redirectURI {
+		return nil, ErrInvalidGrant
+	}
+
+	if !verifyPKCE(authCode.Method, authCode.Challenge, verifier) {
+		return nil, ErrInvalidGrant
+	}
+
+	result := s.DB.Model(authCode).Where("used = ?", false).Update("used", true)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInvalidGrant
+	}
+
+	return authCode, nil
+}
+
+// verifyPKCE checks verifier against the challenge recorded for an
+// authorization code per RFC 7636. An empty challenge means the client
+// didn't use PKCE, so any (or no) verifier is accepted.
+func verifyPKCE(method, challenge, verifier string) bool {
+	if challenge == "" {
+		return true
+	}
+
+	if verifier == "" {
+		return false
+	}
+
+	switch method {
+	case "", "plain":
+		return subtle.ConstantTimeCompare([]byte(challenge), []byte(verifier)) == 1
+	case "S256":
+		sum := sha256.Sum256([]byte(verifier))
+		computed := base64.RawURLEncoding.EncodeToString(sum[:])
+		return subtle.ConstantTimeCompare([]byte(challenge), []byte(computed)) == 1
+	default:
+		return false
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import "testing"
+
+func TestVerifyPKCE(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		challenge string
+		verifier  string
+		want      bool
+	}{
+		{"no challenge accepts no verifier", "", "", "", true},
+		{"no challenge accepts any verifier", "S256", "", "irrelevant", true},
+		{"plain match", "plain", "abc123", "abc123", true},
+		{"plain mismatch", "plain", "abc123", "wrong", false},
+		{"empty method defaults to plain", "", "abc123", "abc123", true},
+		{"non-empty challenge with no verifier rejected", "plain", "abc123", "", false},
+		{"s256 match", "S256", "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk", true},
+		{"s256 mismatch", "S256", "wrong-challenge", "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk", false},
+		{"unknown method rejected", "unknown", "abc123", "abc123", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyPKCE(tt.method, tt.challenge, tt.verifier); got != tt.want {
+				t.Errorf("verifyPKCE(%q, %q, %q) = %v, want %v", tt.method, tt.challenge, tt.verifier, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package services
+
+import "errors"
+
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrInvalidCredentials  = errors.New("invalid_credentials")
+	ErrInvalidClient       = errors.New("invalid_client")
+	ErrEmailTaken          = errors.New("email_taken")
+	ErrInvalidGrant        = errors.New("invalid_grant")
+	ErrNoFactorsEnrolled   = errors.New("no_factors_enrolled")
+	ErrChallengeExpired    = errors.New("challenge_expired")
+	ErrInvalidFactor       = errors.New("invalid_factor")
+	ErrInvalidFactorSecret = errors.New("invalid_factor_secret")
+	ErrTicketRevoked       = errors.New("ticket_revoked")
+)
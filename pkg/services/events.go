@@ -0,0 +1,72 @@
+package services
+
+import (
+	"crypto/rand"
+
+	"github.com/lucsky/cuid"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+const maxEventsPageSize = 100
+
+// AddEvent records a security-sensitive action against the audit log.
+// AccountID may be empty for actions with no associated Account, such as a
+// client_credentials grant, and clientID may be empty for actions with no
+// associated client, such as a login or a failed challenge.
+func (s *Service) AddEvent(accountID, clientID, action, target, ip, userAgent string) error {
+	id, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.Create(&models.ActionEvent{
+		ID:        id,
+		AccountID: accountID,
+		ClientID:  clientID,
+		Action:    action,
+		Target:    target,
+		IP:        ip,
+		UserAgent: userAgent,
+	}).Error
+}
+
+// ListAccountEvents returns accountID's audit log, most recent first.
+func (s *Service) ListAccountEvents(accountID string, limit, offset int) ([]models.ActionEvent, error) {
+	if limit <= 0 || limit > maxEventsPageSize {
+		limit = maxEventsPageSize
+	}
+
+	var events []models.ActionEvent
+	err := s.DB.Where("account_id = ?", accountID).
+		Order("created_at desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error
+
+	return events, err
+}
+
+// ListEvents returns the audit log across every Account, optionally filtered
+// by accountID, clientID, and/or action, most recent first.
+func (s *Service) ListEvents(accountID, clientID, action string, limit, offset int) ([]models.ActionEvent, error) {
+	if limit <= 0 || limit > maxEventsPageSize {
+		limit = maxEventsPageSize
+	}
+
+	query := s.DB.Order("created_at desc").Limit(limit).Offset(offset)
+	if accountID != "" {
+		query = query.Where("account_id = ?", accountID)
+	}
+	if clientID != "" {
+		query = query.Where("client_id = ?", clientID)
+	}
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	var events []models.ActionEvent
+	err := query.Find(&events).Error
+
+	return events, err
+}
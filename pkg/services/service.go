@@ -0,0 +1,14 @@
+package services
+
+import "gorm.io/gorm"
+
+// Service holds the business logic for the authorization server, independent
+// of the Fiber handlers that call into it.
+type Service struct {
+	DB            *gorm.DB
+	SessionSecret string
+}
+
+func New(db *gorm.DB, sessionSecret string) *Service {
+	return &Service{DB: db, SessionSecret: sessionSecret}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+const SessionTTL = 30 * 24 * time.Hour
+
+// IssueSessionToken signs a session JWT for accountID, to be set as the
+// session-token cookie by the caller.
+func (s *Service) IssueSessionToken(accountID string) (string, time.Time, error) {
+	expires := time.Now().Add(SessionTTL)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": accountID,
+		"exp": expires.Unix(),
+	})
+
+	signed, err := token.SignedString([]byte(s.SessionSecret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expires, nil
+}
+
+// AccountFromSessionToken verifies a session-token cookie value and loads the
+// Account it names. Returns an error for a missing, invalid, expired, or
+// dangling session.
+func (s *Service) AccountFromSessionToken(sessionToken string) (*models.Account, error) {
+	token, err := jwt.Parse(sessionToken, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.SessionSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidCredentials
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	accountID, _ := claims["sub"].(string)
+	if accountID == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.LookupAccount(accountID)
+}
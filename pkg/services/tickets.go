@@ -0,0 +1,123 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"github.com/lucsky/cuid"
+	"gorm.io/gorm"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+// CreateTicket records the server-side session for an access token minted at
+// /token, so the Account can later list or remotely revoke it.
+func (s *Service) CreateTicket(client *models.Client, accountID, accessJTI, refreshToken, ip, userAgent string, expiresAt time.Time) error {
+	id, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.Create(&models.Ticket{
+		ID:           id,
+		AccountID:    accountID,
+		ClientID:     client.ID,
+		AccessJTI:    accessJTI,
+		RefreshToken: refreshToken,
+		IP:           ip,
+		UserAgent:    userAgent,
+		LastUsedAt:   time.Now(),
+		ExpiresAt:    expiresAt,
+	}).Error
+}
+
+// TicketByJTI finds the Ticket recording accessJTI's session, so a caller
+// can check which Client it was issued to. Returns nil (with no error) when
+// accessJTI has no Ticket.
+func (s *Service) TicketByJTI(accessJTI string) (*models.Ticket, error) {
+	ticket := new(models.Ticket)
+	if err := s.DB.First(ticket, "access_jti = ?", accessJTI).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// ValidateTicket is called by the JWT middleware on every authenticated
+// request: it rejects a jti whose Ticket was revoked and bumps LastUsedAt
+// for the ones that weren't.
+func (s *Service) ValidateTicket(jti string) error {
+	ticket := new(models.Ticket)
+	if err := s.DB.First(ticket, "access_jti = ?", jti).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if ticket.RevokedAt.Valid {
+		return ErrTicketRevoked
+	}
+
+	return s.DB.Model(ticket).Update("last_used_at", time.Now()).Error
+}
+
+// RevokeTicketByJTI marks the Ticket for accessJTI revoked, used when a
+// client revokes the access token directly via /revoke. It also
+// revokes the Ticket's RefreshToken so the session can't simply be renewed.
+// The returned Ticket is nil (with no error) when accessJTI has no Ticket.
+func (s *Service) RevokeTicketByJTI(accessJTI string) (*models.Ticket, error) {
+	ticket := new(models.Ticket)
+	if err := s.DB.First(ticket, "access_jti = ?", accessJTI).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := s.revokeTicketAndRefreshToken(ticket); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// ListAccountTickets returns accountID's active and revoked sessions, most
+// recently used first.
+func (s *Service) ListAccountTickets(accountID string) ([]models.Ticket, error) {
+	var tickets []models.Ticket
+	err := s.DB.Where("account_id = ?", accountID).Order("last_used_at desc").Find(&tickets).Error
+	return tickets, err
+}
+
+// RevokeTicket revokes accountID's own ticketID, so it can no longer be used
+// to validate an access token.
+func (s *Service) RevokeTicket(accountID, ticketID string) (*models.Ticket, error) {
+	ticket := new(models.Ticket)
+	if err := s.DB.First(ticket, "id = ? AND account_id = ?", ticketID, accountID).Error; err != nil {
+		return nil, ErrNotFound
+	}
+
+	if err := s.revokeTicketAndRefreshToken(ticket); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// revokeTicketAndRefreshToken marks both ticket and the RefreshToken it was
+// issued alongside as revoked, so a revoked session can't be renewed via
+// RedeemRefreshToken.
+func (s *Service) revokeTicketAndRefreshToken(ticket *models.Ticket) error {
+	now := time.Now()
+
+	if err := s.DB.Model(ticket).Update("revoked_at", now).Error; err != nil {
+		return err
+	}
+
+	return s.DB.Model(&models.RefreshToken{}).Where("token = ?", ticket.RefreshToken).Update("revoked_at", now).Error
+}
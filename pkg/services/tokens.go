@@ -0,0 +1,262 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lucsky/cuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/unsubstantiated-Script/GoAuth/pkg/models"
+)
+
+const (
+	AccessTokenTTL  = 6 * time.Hour
+	RefreshTokenTTL = 30 * 24 * time.Hour
+	SigningKeyTTL   = 90 * 24 * time.Hour
+)
+
+// TokenPair is what /token hands back to a client: an access token, how long
+// it lives, and a refresh token to mint the next one. AccountID is the
+// Account the tokens were minted for (empty for a client_credentials grant),
+// for callers that need to attribute the mint without re-deriving it.
+type TokenPair struct {
+	AccessToken  string
+	ExpiresIn    int
+	RefreshToken string
+	AccountID    string
+}
+
+// generateSigningKey creates and persists a new RSA signing key.
+func (s *Service) generateSigningKey() (*models.SigningKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &models.SigningKey{
+		Kid:        kid,
+		Alg:        models.AlgRS256,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})),
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes})),
+		NotBefore:  time.Now(),
+		NotAfter:   time.Now().Add(SigningKeyTTL),
+	}
+
+	if err := s.DB.Create(key).Error; err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// CurrentSigningKey returns the active signing key, minting a new one when no
+// stored key will stay valid for at least AccessTokenTTL. A key therefore
+// stops signing AccessTokenTTL before its NotAfter, so every token it signed
+// expires while the key is still published in the JWKS.
+func (s *Service) CurrentSigningKey() (*models.SigningKey, error) {
+	now := time.Now()
+
+	key := new(models.SigningKey)
+	err := s.DB.Where("not_before <= ? AND not_after > ?", now, now.Add(AccessTokenTTL)).Order("not_before desc").First(key).Error
+	if err == nil {
+		return key, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return s.generateSigningKey()
+}
+
+// ActiveSigningKeys returns every signing key still valid for JWKS
+// publication, including ones past their NotBefore that are no longer the
+// current signer but may still be verifying previously issued tokens.
+func (s *Service) ActiveSigningKeys() ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	if err := s.DB.Where("not_after > ?", time.Now()).Find(&keys).Error; err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func ParseRSAPrivateKey(pemEncoded string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemEncoded))
+	if block == nil {
+		return nil, errors.New("invalid signing key PEM")
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+func ParseRSAPublicKey(pemEncoded string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemEncoded))
+	if block == nil {
+		return nil, errors.New("invalid signing key PEM")
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("signing key is not RSA")
+	}
+
+	return publicKey, nil
+}
+
+// ParseAccessToken verifies an access token against the RSA public key named
+// by its kid header, so the server can validate tokens it signed without
+// re-deriving a client secret.
+func (s *Service) ParseAccessToken(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("token has no kid")
+		}
+
+		signingKey := new(models.SigningKey)
+		if err := s.DB.First(signingKey, "kid = ?", kid).Error; err != nil {
+			return nil, err
+		}
+
+		return ParseRSAPublicKey(signingKey.PublicKey)
+	})
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// IsRevoked reports whether jti has been marked revoked.
+func (s *Service) IsRevoked(jti string) bool {
+	return s.DB.First(new(models.RevokedToken), "jti = ?", jti).Error == nil
+}
+
+// Revoke marks an access token's jti as revoked (RFC 7009).
+func (s *Service) Revoke(jti string) error {
+	return s.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.RevokedToken{JTI: jti, RevokedAt: time.Now()}).Error
+}
+
+// MintTokens issues an access token for client (and optionally accountID and
+// scope) along with a fresh, persisted refresh token. Every access token is
+// signed with the current rotating RSA key and a kid header so resource
+// servers can verify via the JWKS instead of holding a shared secret.
+// ip and userAgent are recorded on the Ticket the JWT middleware uses to
+// track and remotely revoke the resulting session.
+func (s *Service) MintTokens(client *models.Client, accountID, scope, ip, userAgent string) (*TokenPair, error) {
+	jti, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{
+		"jti": jti,
+		"exp": time.Now().Add(AccessTokenTTL).Unix(),
+	}
+
+	if scope != "" {
+		claims["scope"] = scope
+	}
+
+	if accountID != "" {
+		if account, err := s.LookupAccount(accountID); err == nil {
+			claims["username"] = account.Nick
+			claims["user_id"] = account.ID
+		}
+	}
+
+	signingKey, err := s.CurrentSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := ParseRSAPrivateKey(signingKey.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = signingKey.Kid
+	accessToken, err := token.SignedString(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := cuid.NewCrypto(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.DB.Create(&models.RefreshToken{
+		Token:     refreshToken,
+		ClientID:  client.ID,
+		AccountID: accountID,
+		Scope:     scope,
+		ExpiresAt: time.Now().Add(RefreshTokenTTL),
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := s.CreateTicket(client, accountID, jti, refreshToken, ip, userAgent, time.Now().Add(AccessTokenTTL)); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		ExpiresIn:    int(AccessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+		AccountID:    accountID,
+	}, nil
+}
+
+// RedeemRefreshToken rotates a stored refresh token for a fresh token pair,
+// revoking the one that was presented. The revoke is a conditional update so
+// two concurrent redemptions of the same token can't both succeed.
+func (s *Service) RedeemRefreshToken(client *models.Client, refreshToken, ip, userAgent string) (*TokenPair, error) {
+	stored := new(models.RefreshToken)
+	if err := s.DB.First(stored, "token = ? AND client_id = ?", refreshToken, client.ID).Error; err != nil {
+		return nil, ErrInvalidGrant
+	}
+
+	if stored.RevokedAt.Valid || time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidGrant
+	}
+
+	result := s.DB.Model(stored).Where("revoked_at IS NULL").Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInvalidGrant
+	}
+
+	return s.MintTokens(client, stored.AccountID, stored.Scope, ip, userAgent)
+}
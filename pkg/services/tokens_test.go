@@ -0,0 +1,52 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestParseRSAKeyRoundTrip(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	privatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}))
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	publicPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}))
+
+	parsedPrivate, err := ParseRSAPrivateKey(privatePEM)
+	if err != nil {
+		t.Fatalf("ParseRSAPrivateKey: %v", err)
+	}
+	if !parsedPrivate.Equal(privateKey) {
+		t.Error("parsed private key does not match the original")
+	}
+
+	parsedPublic, err := ParseRSAPublicKey(publicPEM)
+	if err != nil {
+		t.Fatalf("ParseRSAPublicKey: %v", err)
+	}
+	if !parsedPublic.Equal(&privateKey.PublicKey) {
+		t.Error("parsed public key does not match the original")
+	}
+}
+
+func TestParseRSAPrivateKeyInvalidPEM(t *testing.T) {
+	if _, err := ParseRSAPrivateKey("not a pem"); err == nil {
+		t.Error("expected an error for input with no PEM block")
+	}
+}
+
+func TestParseRSAPublicKeyInvalidPEM(t *testing.T) {
+	if _, err := ParseRSAPublicKey("not a pem"); err == nil {
+		t.Error("expected an error for input with no PEM block")
+	}
+}